@@ -0,0 +1,22 @@
+package diagnostics
+
+import (
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnosticsCancelCommand aborts a diagnostics bundle that is still being created.
+func newDiagnosticsCancelCommand(ctx api.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "cancel <bundle-id>",
+		Short: "Cancel an in-progress diagnostics bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient(ctx)
+			if err != nil {
+				return err
+			}
+			return c.Cancel(args[0])
+		},
+	}
+}
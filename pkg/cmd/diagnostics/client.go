@@ -0,0 +1,262 @@
+package diagnostics
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/httpclient"
+)
+
+// diagnosticsBasePath is the cluster endpoint serving the diagnostics bundle API.
+const diagnosticsBasePath = "/system/health/v1/diagnostics"
+
+// Bundle is a diagnostics bundle along with its creation status and the
+// per-node status of the nodes it was collected from.
+type Bundle struct {
+	ID      string        `json:"id"`
+	Status  string        `json:"status"`
+	Started string        `json:"started_at,omitempty"`
+	Stopped string        `json:"stopped_at,omitempty"`
+	Nodes   []*BundleNode `json:"nodes,omitempty"`
+}
+
+// BundleNode is the status of a single node's contribution to a bundle.
+type BundleNode struct {
+	IP     string `json:"ip"`
+	Status string `json:"status"`
+	Error  string `json:"error_message,omitempty"`
+}
+
+// Done reports whether the bundle has reached a terminal state.
+func (b *Bundle) Done() bool {
+	return b.Status == "Done" || b.Status == "Canceled"
+}
+
+// Failed reports whether any node failed to contribute to the bundle.
+func (b *Bundle) Failed() bool {
+	for _, node := range b.Nodes {
+		if node.Status == "Failed" {
+			return true
+		}
+	}
+	return false
+}
+
+// client is a small typed HTTP client for the diagnostics bundle API, shared
+// by all the diagnostics subcommands so they don't issue raw HTTP calls.
+type client struct {
+	http *httpclient.Client
+}
+
+// newClient creates a diagnostics client for the current cluster of ctx.
+func newClient(ctx api.Context) (*client, error) {
+	cluster, err := ctx.Cluster()
+	if err != nil {
+		return nil, err
+	}
+	httpClient, err := ctx.HTTPClient(cluster)
+	if err != nil {
+		return nil, err
+	}
+	return &client{http: httpClient}, nil
+}
+
+// List returns every diagnostics bundle known to the cluster.
+func (c *client) List() ([]*Bundle, error) {
+	resp, err := c.http.Get(diagnosticsBasePath + "/list")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := expectOK(resp); err != nil {
+		return nil, err
+	}
+
+	var bundles []*Bundle
+	if err := json.NewDecoder(resp.Body).Decode(&bundles); err != nil {
+		return nil, err
+	}
+	return bundles, nil
+}
+
+// Create starts a new diagnostics bundle for the given nodes. An empty nodes
+// slice collects the bundle from every node in the cluster.
+func (c *client) Create(nodes []string) (*Bundle, error) {
+	body, err := json.Marshal(map[string][]string{"nodes": nodes})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.http.Post(diagnosticsBasePath, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := expectOK(resp); err != nil {
+		return nil, err
+	}
+
+	var bundle Bundle
+	if err := json.NewDecoder(resp.Body).Decode(&bundle); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// Inspect returns the metadata and per-node status of a single bundle.
+func (c *client) Inspect(id string) (*Bundle, error) {
+	resp, err := c.http.Get(diagnosticsBasePath + "/" + id)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("bundle %s not found", id)
+	}
+	if err := expectOK(resp); err != nil {
+		return nil, err
+	}
+
+	var bundle Bundle
+	if err := json.NewDecoder(resp.Body).Decode(&bundle); err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// downloadAction is how Download should reconcile a response's status code
+// with the offset it requested, ahead of copying any response body.
+type downloadAction int
+
+const (
+	// downloadAppend means the response body continues from offset and
+	// should be appended to f as-is.
+	downloadAppend downloadAction = iota
+	// downloadRestart means the response body is the full bundle and f's
+	// existing partial content must be discarded first.
+	downloadRestart
+	// downloadSkip means there is nothing left to copy.
+	downloadSkip
+)
+
+// decideDownloadAction interprets a download response's status code,
+// accounting for servers that don't honor the Range header Download sends
+// when offset > 0 and reply with a full 200 instead of a 206. The second
+// return value is false for any status Download doesn't recognize, leaving
+// the caller to turn the response itself into an error via httpError so
+// every client method reports failures the same way.
+func decideDownloadAction(status int, offset int64) (downloadAction, bool) {
+	switch status {
+	case http.StatusPartialContent:
+		return downloadAppend, true
+	case http.StatusOK:
+		if offset > 0 {
+			return downloadRestart, true
+		}
+		return downloadAppend, true
+	case http.StatusRequestedRangeNotSatisfiable:
+		return downloadSkip, true
+	default:
+		return 0, false
+	}
+}
+
+// Download streams the bundle's zip file into f, starting at offset bytes so
+// that interrupted downloads can be resumed. f must be positioned so that
+// writes continue from offset (as when opened with os.O_APPEND); if the
+// server does not honor the Range request and sends the full bundle back
+// instead, Download truncates f and starts over from the beginning.
+func (c *client) Download(id string, f *os.File, offset int64) error {
+	var opts []httpclient.Option
+	if offset > 0 {
+		opts = append(opts, httpclient.Header("Range", "bytes="+strconv.FormatInt(offset, 10)+"-"))
+	}
+
+	resp, err := c.http.Get(diagnosticsBasePath+"/"+id+"/file", opts...)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	action, ok := decideDownloadAction(resp.StatusCode, offset)
+	if !ok {
+		return httpError(resp)
+	}
+
+	switch action {
+	case downloadSkip:
+		return nil
+	case downloadRestart:
+		if err := f.Truncate(0); err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	_, err = io.Copy(f, resp.Body)
+	return err
+}
+
+// Delete removes a diagnostics bundle from the cluster.
+func (c *client) Delete(id string) error {
+	resp, err := c.http.Delete(diagnosticsBasePath + "/" + id)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return expectOK(resp)
+}
+
+// Cancel aborts a diagnostics bundle that is still being created.
+func (c *client) Cancel(id string) error {
+	resp, err := c.http.Post(diagnosticsBasePath+"/"+id+"/cancel", "application/json", nil)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	return expectOK(resp)
+}
+
+// Stream opens a long-lived connection to the diagnostics service logs and
+// copies every event to w until the connection is closed. It backs the
+// experimental "diagnostics stream" subcommand.
+func (c *client) Stream(w io.Writer) error {
+	resp, err := c.http.Get(diagnosticsBasePath + "/stream")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if err := expectOK(resp); err != nil {
+		return err
+	}
+
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
+
+// expectOK returns an error built from resp unless it has status 200 OK.
+func expectOK(resp *http.Response) error {
+	if resp.StatusCode != http.StatusOK {
+		return httpError(resp)
+	}
+	return nil
+}
+
+// httpError turns a non-2xx diagnostics API response into an error.
+func httpError(resp *http.Response) error {
+	return fmt.Errorf("diagnostics API returned %s", resp.Status)
+}
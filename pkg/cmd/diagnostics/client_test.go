@@ -0,0 +1,83 @@
+package diagnostics
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestDecideDownloadAction(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		offset int64
+		want   downloadAction
+		wantOK bool
+	}{
+		{"partial content resumes a download", http.StatusPartialContent, 512, downloadAppend, true},
+		{"ok with no offset is a fresh download", http.StatusOK, 0, downloadAppend, true},
+		{"ok with an offset means the server ignored Range", http.StatusOK, 512, downloadRestart, true},
+		{"range not satisfiable means nothing left to fetch", http.StatusRequestedRangeNotSatisfiable, 512, downloadSkip, true},
+		{"any other status is unrecognized", http.StatusInternalServerError, 512, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := decideDownloadAction(tt.status, tt.offset)
+			if ok != tt.wantOK {
+				t.Fatalf("decideDownloadAction(%d, %d) ok = %v, want %v", tt.status, tt.offset, ok, tt.wantOK)
+			}
+			if ok && got != tt.want {
+				t.Fatalf("decideDownloadAction(%d, %d) = %v, want %v", tt.status, tt.offset, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpectOK(t *testing.T) {
+	tests := []struct {
+		status  int
+		wantErr bool
+	}{
+		{http.StatusOK, false},
+		{http.StatusCreated, true},
+		{http.StatusNotFound, true},
+		{http.StatusInternalServerError, true},
+	}
+
+	for _, tt := range tests {
+		resp := &http.Response{StatusCode: tt.status, Status: http.StatusText(tt.status)}
+		err := expectOK(resp)
+		if tt.wantErr && err == nil {
+			t.Errorf("expectOK(%d) = nil error, want one", tt.status)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("expectOK(%d) returned unexpected error: %v", tt.status, err)
+		}
+	}
+}
+
+func TestBundleDoneAndFailed(t *testing.T) {
+	tests := []struct {
+		name       string
+		bundle     Bundle
+		wantDone   bool
+		wantFailed bool
+	}{
+		{"started", Bundle{Status: "Started"}, false, false},
+		{"done", Bundle{Status: "Done"}, true, false},
+		{"canceled", Bundle{Status: "Canceled"}, true, false},
+		{"done with a failed node", Bundle{Status: "Done", Nodes: []*BundleNode{{IP: "10.0.0.1", Status: "Failed"}}}, true, true},
+		{"done with only healthy nodes", Bundle{Status: "Done", Nodes: []*BundleNode{{IP: "10.0.0.1", Status: "Done"}}}, true, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.bundle.Done(); got != tt.wantDone {
+				t.Errorf("Done() = %v, want %v", got, tt.wantDone)
+			}
+			if got := tt.bundle.Failed(); got != tt.wantFailed {
+				t.Errorf("Failed() = %v, want %v", got, tt.wantFailed)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package diagnostics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnosticsCreateCommand creates a new diagnostics bundle.
+func newDiagnosticsCreateCommand(ctx api.Context) *cobra.Command {
+	var nodes []string
+	var wait bool
+
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Create a new diagnostics bundle",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			bundle, err := c.Create(nodes)
+			if err != nil {
+				return err
+			}
+
+			if wait {
+				bundle, err = waitForBundle(c, bundle.ID)
+				if err != nil {
+					return err
+				}
+				if bundle.Failed() {
+					fmt.Fprintln(ctx.ErrOut(), "one or more nodes failed to contribute to the bundle")
+					return fmt.Errorf("bundle %s completed with errors", bundle.ID)
+				}
+			}
+
+			fmt.Fprintln(ctx.Out(), bundle.ID)
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVar(&nodes, "node-selector", nil, "Only collect the bundle from these nodes (default: all nodes)")
+	cmd.Flags().BoolVar(&wait, "wait", false, "Block until the bundle creation is done")
+	return cmd
+}
+
+// waitForBundle polls the diagnostics API until the bundle reaches a terminal state.
+func waitForBundle(c *client, id string) (*Bundle, error) {
+	for {
+		bundle, err := c.Inspect(id)
+		if err != nil {
+			return nil, err
+		}
+		if bundle.Done() {
+			return bundle, nil
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
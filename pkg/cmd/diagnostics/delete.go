@@ -0,0 +1,22 @@
+package diagnostics
+
+import (
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnosticsDeleteCommand removes a diagnostics bundle from the cluster.
+func newDiagnosticsDeleteCommand(ctx api.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delete <bundle-id>",
+		Short: "Delete a diagnostics bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient(ctx)
+			if err != nil {
+				return err
+			}
+			return c.Delete(args[0])
+		},
+	}
+}
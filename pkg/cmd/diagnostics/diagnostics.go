@@ -2,16 +2,38 @@ package diagnostics
 
 import (
 	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/cmd/internal/cobraext"
 	"github.com/spf13/cobra"
 )
 
 // NewCommand creates and returns a diagnostics command with its subcommands
 // already added.
 func NewCommand(ctx api.Context) *cobra.Command {
+	cobraext.RegisterTemplateFuncs()
+
 	cmd := &cobra.Command{
 		Use:   "diagnostics",
 		Short: "Create and manage DCOS diagnostics bundles",
 	}
-	cmd.AddCommand(newDiagnosticsListCommand(ctx))
+	cmd.SetHelpTemplate(cobraext.HelpTemplate)
+	cobraext.RegisterExperimentalFlag(cmd)
+
+	for _, sub := range []*cobra.Command{
+		newDiagnosticsListCommand(ctx),
+		newDiagnosticsCreateCommand(ctx),
+		newDiagnosticsInspectCommand(ctx),
+		newDiagnosticsDownloadCommand(ctx),
+		newDiagnosticsDeleteCommand(ctx),
+		newDiagnosticsCancelCommand(ctx),
+	} {
+		cobraext.MarkOperation(sub)
+		cmd.AddCommand(sub)
+	}
+
+	stream := newDiagnosticsStreamCommand(ctx)
+	cmd.AddCommand(stream)
+	cobraext.MarkExperimental(stream)
+	cobraext.SetExperimentalHelpFunc(cmd)
+
 	return cmd
 }
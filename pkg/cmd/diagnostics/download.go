@@ -0,0 +1,58 @@
+package diagnostics
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnosticsDownloadCommand downloads a diagnostics bundle's zip file.
+func newDiagnosticsDownloadCommand(ctx api.Context) *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "download <bundle-id>",
+		Short: "Download a diagnostics bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id := args[0]
+			if output == "" {
+				output = id + ".zip"
+			}
+
+			var offset int64
+			if info, err := os.Stat(output); err == nil {
+				offset = info.Size()
+			}
+
+			flags := os.O_CREATE | os.O_WRONLY
+			if offset > 0 {
+				flags |= os.O_APPEND
+			} else {
+				flags |= os.O_TRUNC
+			}
+
+			f, err := os.OpenFile(output, flags, 0644)
+			if err != nil {
+				return err
+			}
+			defer f.Close()
+
+			c, err := newClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			if err := c.Download(id, f, offset); err != nil {
+				return err
+			}
+
+			fmt.Fprintln(ctx.Out(), output)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&output, "output", "", "Path to write the bundle to (default: <bundle-id>.zip)")
+	return cmd
+}
@@ -0,0 +1,64 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/template"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnosticsInspectCommand prints the metadata and per-node status of a diagnostics bundle.
+func newDiagnosticsInspectCommand(ctx api.Context) *cobra.Command {
+	var jsonOutput bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "inspect <bundle-id>",
+		Short: "Display detailed information about a diagnostics bundle",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			bundle, err := c.Inspect(args[0])
+			if err != nil {
+				return err
+			}
+
+			switch {
+			case format != "":
+				tmpl, err := template.New("inspect").Parse(format)
+				if err != nil {
+					return err
+				}
+				if err := tmpl.Execute(ctx.Out(), bundle); err != nil {
+					return err
+				}
+				fmt.Fprintln(ctx.Out())
+			case jsonOutput:
+				enc := json.NewEncoder(ctx.Out())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(bundle); err != nil {
+					return err
+				}
+			default:
+				fmt.Fprintf(ctx.Out(), "%s  %s\n", bundle.ID, bundle.Status)
+				for _, node := range bundle.Nodes {
+					fmt.Fprintf(ctx.Out(), "  %-16s %s\n", node.IP, node.Status)
+				}
+			}
+
+			if bundle.Failed() {
+				return fmt.Errorf("one or more nodes failed to contribute to bundle %s", bundle.ID)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the bundle metadata in JSON format")
+	cmd.Flags().StringVar(&format, "format", "", "Format the output using a Go template")
+	return cmd
+}
@@ -0,0 +1,52 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/dcos/dcos-cli/api"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnosticsListCommand lists every diagnostics bundle known to the cluster.
+func newDiagnosticsListCommand(ctx api.Context) *cobra.Command {
+	var jsonOutput bool
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List diagnostics bundles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := newClient(ctx)
+			if err != nil {
+				return err
+			}
+
+			bundles, err := c.List()
+			if err != nil {
+				return err
+			}
+
+			if jsonOutput {
+				enc := json.NewEncoder(ctx.Out())
+				enc.SetIndent("", "  ")
+				if err := enc.Encode(bundles); err != nil {
+					return err
+				}
+			} else {
+				for _, bundle := range bundles {
+					fmt.Fprintf(ctx.Out(), "%s  %s\n", bundle.ID, bundle.Status)
+				}
+			}
+
+			for _, bundle := range bundles {
+				if bundle.Failed() {
+					return fmt.Errorf("one or more bundles have a node that failed to contribute")
+				}
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolVar(&jsonOutput, "json", false, "Print the bundles in JSON format")
+	return cmd
+}
@@ -0,0 +1,29 @@
+package diagnostics
+
+import (
+	"github.com/dcos/dcos-cli/api"
+	"github.com/dcos/dcos-cli/pkg/cmd/internal/cobraext"
+	"github.com/spf13/cobra"
+)
+
+// newDiagnosticsStreamCommand tails the diagnostics service logs over a
+// long-lived connection to the cluster. It is experimental: see
+// cobraext.MarkExperimental, applied by the caller once the command is
+// attached to the diagnostics command tree.
+func newDiagnosticsStreamCommand(ctx api.Context) *cobra.Command {
+	return &cobra.Command{
+		Use:   "stream",
+		Short: "Tail the diagnostics service logs (experimental)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := cobraext.RequireExperimental(cmd, ctx.ErrOut()); err != nil {
+				return err
+			}
+
+			c, err := newClient(ctx)
+			if err != nil {
+				return err
+			}
+			return c.Stream(ctx.Out())
+		},
+	}
+}
@@ -0,0 +1,137 @@
+package cobraext
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func newTestTree() *cobra.Command {
+	root := &cobra.Command{Use: "diagnostics", Run: func(*cobra.Command, []string) {}}
+
+	list := &cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}}
+	create := &cobra.Command{Use: "create", Run: func(*cobra.Command, []string) {}}
+	node := &cobra.Command{Use: "node", Run: func(*cobra.Command, []string) {}}
+	node.AddCommand(&cobra.Command{Use: "list", Run: func(*cobra.Command, []string) {}})
+
+	MarkOperation(list)
+	MarkOperation(create)
+	MarkManagement(node)
+
+	root.AddCommand(list, create, node)
+	return root
+}
+
+func TestOperationAndManagementSubCommands(t *testing.T) {
+	root := newTestTree()
+
+	if !hasSubCommands(root) {
+		t.Error("hasSubCommands(root) = false, want true")
+	}
+	if !hasManagementSubCommands(root) {
+		t.Error("hasManagementSubCommands(root) = false, want true")
+	}
+
+	ops := operationSubCommands(root)
+	if len(ops) != 2 {
+		t.Fatalf("operationSubCommands(root) returned %d commands, want 2", len(ops))
+	}
+	for _, cmd := range ops {
+		if cmd.Name() == "node" {
+			t.Errorf("operationSubCommands(root) unexpectedly included management command %q", cmd.Name())
+		}
+	}
+
+	mgmt := managementSubCommands(root)
+	if len(mgmt) != 1 || mgmt[0].Name() != "node" {
+		t.Fatalf("managementSubCommands(root) = %v, want [node]", mgmt)
+	}
+}
+
+func TestHasSubCommandsFalseWhenOnlyManagement(t *testing.T) {
+	root := &cobra.Command{Use: "diagnostics"}
+	node := &cobra.Command{Use: "node", Run: func(*cobra.Command, []string) {}}
+	MarkManagement(node)
+	root.AddCommand(node)
+
+	if hasSubCommands(root) {
+		t.Error("hasSubCommands(root) = true, want false when only management subcommands exist")
+	}
+	if !hasManagementSubCommands(root) {
+		t.Error("hasManagementSubCommands(root) = false, want true")
+	}
+}
+
+func TestMarkExperimentalAndIsExperimental(t *testing.T) {
+	cmd := &cobra.Command{Use: "stream"}
+	if IsExperimental(cmd) {
+		t.Fatal("IsExperimental(cmd) = true before MarkExperimental was called")
+	}
+
+	MarkExperimental(cmd)
+
+	if !IsExperimental(cmd) {
+		t.Error("IsExperimental(cmd) = false after MarkExperimental was called")
+	}
+}
+
+func TestExperimentalEnabled(t *testing.T) {
+	newRoot := func() *cobra.Command {
+		root := &cobra.Command{Use: "diagnostics"}
+		RegisterExperimentalFlag(root)
+		return root
+	}
+
+	t.Run("disabled by default", func(t *testing.T) {
+		root := newRoot()
+		if ExperimentalEnabled(root) {
+			t.Error("ExperimentalEnabled(root) = true, want false with no flag or env var set")
+		}
+	})
+
+	t.Run("enabled via flag", func(t *testing.T) {
+		root := newRoot()
+		if err := root.PersistentFlags().Set("experimental", "true"); err != nil {
+			t.Fatalf("Set(experimental, true) failed: %v", err)
+		}
+		if !ExperimentalEnabled(root) {
+			t.Error("ExperimentalEnabled(root) = false, want true once --experimental is set")
+		}
+	})
+
+	t.Run("enabled via env var", func(t *testing.T) {
+		t.Setenv("DCOS_EXPERIMENTAL", "1")
+		root := newRoot()
+		if !ExperimentalEnabled(root) {
+			t.Error("ExperimentalEnabled(root) = false, want true with DCOS_EXPERIMENTAL=1")
+		}
+	})
+}
+
+func TestRequireExperimental(t *testing.T) {
+	root := &cobra.Command{Use: "diagnostics"}
+	RegisterExperimentalFlag(root)
+	stream := &cobra.Command{Use: "stream"}
+	root.AddCommand(stream)
+	MarkExperimental(stream)
+
+	var errOut bytes.Buffer
+	if err := RequireExperimental(stream, &errOut); err == nil {
+		t.Error("RequireExperimental(stream) = nil error, want one when experimental is disabled")
+	}
+	if errOut.Len() != 0 {
+		t.Errorf("RequireExperimental(stream) wrote %q to errOut, want nothing when rejecting", errOut.String())
+	}
+
+	if err := root.PersistentFlags().Set("experimental", "true"); err != nil {
+		t.Fatalf("Set(experimental, true) failed: %v", err)
+	}
+	errOut.Reset()
+	if err := RequireExperimental(stream, &errOut); err != nil {
+		t.Errorf("RequireExperimental(stream) = %v, want nil once experimental is enabled", err)
+	}
+	if errOut.Len() == 0 {
+		t.Error("RequireExperimental(stream) did not print the warning banner to errOut")
+	}
+}